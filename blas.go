@@ -0,0 +1,146 @@
+package mathgl
+
+// BLAS is the set of low-level linear algebra kernels that the typed dense
+// matrix types (DenseF64, DenseF32) dispatch to. A pure-Go implementation is
+// installed by default (see Backend below), but it's deliberately a small,
+// swappable interface so a build can link in a cgo wrapper around an
+// optimized GEMM (OpenBLAS, Accelerate, MKL, ...) without touching any of
+// the call sites in dense.go or matrix.go.
+type BLAS interface {
+	// Dgemm computes C = alpha*op(A)*op(B) + beta*C, where op(X) is X or
+	// X^T depending on transA/transB. A, B, C are row-major with the given
+	// leading dimensions (lda/ldb/ldc), and op(A) is m x k, op(B) is k x n.
+	Dgemm(transA, transB bool, m, n, k int, alpha float64, a []float64, lda int, b []float64, ldb int, beta float64, c []float64, ldc int)
+
+	// Sgemm is the float32 counterpart of Dgemm.
+	Sgemm(transA, transB bool, m, n, k int, alpha float32, a []float32, lda int, b []float32, ldb int, beta float32, c []float32, ldc int)
+
+	// Daxpy computes y = alpha*x + y over n elements, with the given strides.
+	Daxpy(n int, alpha float64, x []float64, incX int, y []float64, incY int)
+
+	// Dscal computes x = alpha*x over n elements of x, with stride incX.
+	Dscal(n int, alpha float64, x []float64, incX int)
+
+	// Saxpy is the float32 counterpart of Daxpy.
+	Saxpy(n int, alpha float32, x []float32, incX int, y []float32, incY int)
+
+	// Sscal is the float32 counterpart of Dscal.
+	Sscal(n int, alpha float32, x []float32, incX int)
+}
+
+// Backend is the BLAS implementation used by the dense matrix fast path.
+// Replace it (e.g. from an init() in a build-tag-guarded file) to link in
+// an accelerated backend:
+//
+//	func init() { mathgl.Backend = myblas.Wrapper{} }
+var Backend BLAS = defaultBLAS{}
+
+// defaultBLAS is a pure-Go BLAS implementation. It's not tuned for cache
+// behavior or SIMD; it exists so the library works with no cgo and no
+// external dependency, and so the dense fast path has a correctness
+// baseline to test an accelerated Backend against.
+type defaultBLAS struct{}
+
+func (defaultBLAS) Dgemm(transA, transB bool, m, n, k int, alpha float64, a []float64, lda int, b []float64, ldb int, beta float64, c []float64, ldc int) {
+	aAt := func(i, p int) float64 {
+		if transA {
+			return a[p*lda+i]
+		}
+		return a[i*lda+p]
+	}
+	bAt := func(p, j int) float64 {
+		if transB {
+			return b[j*ldb+p]
+		}
+		return b[p*ldb+j]
+	}
+
+	for i := 0; i < m; i++ {
+		for j := 0; j < n; j++ {
+			idx := i*ldc + j
+			if beta == 0 {
+				c[idx] = 0
+			} else {
+				c[idx] *= beta
+			}
+		}
+		for p := 0; p < k; p++ {
+			av := alpha * aAt(i, p)
+			if av == 0 {
+				continue
+			}
+			for j := 0; j < n; j++ {
+				c[i*ldc+j] += av * bAt(p, j)
+			}
+		}
+	}
+}
+
+func (defaultBLAS) Sgemm(transA, transB bool, m, n, k int, alpha float32, a []float32, lda int, b []float32, ldb int, beta float32, c []float32, ldc int) {
+	aAt := func(i, p int) float32 {
+		if transA {
+			return a[p*lda+i]
+		}
+		return a[i*lda+p]
+	}
+	bAt := func(p, j int) float32 {
+		if transB {
+			return b[j*ldb+p]
+		}
+		return b[p*ldb+j]
+	}
+
+	for i := 0; i < m; i++ {
+		for j := 0; j < n; j++ {
+			idx := i*ldc + j
+			if beta == 0 {
+				c[idx] = 0
+			} else {
+				c[idx] *= beta
+			}
+		}
+		for p := 0; p < k; p++ {
+			av := alpha * aAt(i, p)
+			if av == 0 {
+				continue
+			}
+			for j := 0; j < n; j++ {
+				c[i*ldc+j] += av * bAt(p, j)
+			}
+		}
+	}
+}
+
+func (defaultBLAS) Daxpy(n int, alpha float64, x []float64, incX int, y []float64, incY int) {
+	xi, yi := 0, 0
+	for i := 0; i < n; i++ {
+		y[yi] += alpha * x[xi]
+		xi += incX
+		yi += incY
+	}
+}
+
+func (defaultBLAS) Dscal(n int, alpha float64, x []float64, incX int) {
+	xi := 0
+	for i := 0; i < n; i++ {
+		x[xi] *= alpha
+		xi += incX
+	}
+}
+
+func (defaultBLAS) Saxpy(n int, alpha float32, x []float32, incX int, y []float32, incY int) {
+	xi, yi := 0, 0
+	for i := 0; i < n; i++ {
+		y[yi] += alpha * x[xi]
+		xi += incX
+		yi += incY
+	}
+}
+
+func (defaultBLAS) Sscal(n int, alpha float32, x []float32, incX int) {
+	xi := 0
+	for i := 0; i < n; i++ {
+		x[xi] *= alpha
+		xi += incX
+	}
+}
@@ -0,0 +1,42 @@
+package mathgl
+
+import "testing"
+
+// TestDefaultBLASDgemmTransposed exercises the transA/transB=true branch of
+// defaultBLAS.Dgemm, which no call site in the package reaches today (every
+// Dgemm call goes through DenseF64.Mul/MulOpts with transA=transB=false).
+func TestDefaultBLASDgemmTransposed(t *testing.T) {
+	// a physically stores A^T (3x2, lda=2); transA=true makes op(A) the
+	// 2x3 matrix [[1,3,5],[2,4,6]].
+	a := []float64{1, 2, 3, 4, 5, 6}
+	// b physically stores B^T (2x3, ldb=3); transB=true makes op(B) the
+	// 3x2 matrix [[1,0],[0,1],[1,1]].
+	b := []float64{1, 0, 1, 0, 1, 1}
+	c := make([]float64, 4)
+	want := []float64{6, 8, 8, 10}
+
+	defaultBLAS{}.Dgemm(true, true, 2, 2, 3, 1, a, 2, b, 3, 0, c, 2)
+
+	for i, v := range want {
+		if c[i] != v {
+			t.Fatalf("c[%d] = %v, want %v", i, c[i], v)
+		}
+	}
+}
+
+// TestDefaultBLASSgemmTransposed is the float32 counterpart of
+// TestDefaultBLASDgemmTransposed.
+func TestDefaultBLASSgemmTransposed(t *testing.T) {
+	a := []float32{1, 2, 3, 4, 5, 6}
+	b := []float32{1, 0, 1, 0, 1, 1}
+	c := make([]float32, 4)
+	want := []float32{6, 8, 8, 10}
+
+	defaultBLAS{}.Sgemm(true, true, 2, 2, 3, 1, a, 2, b, 3, 0, c, 2)
+
+	for i, v := range want {
+		if c[i] != v {
+			t.Fatalf("c[%d] = %v, want %v", i, c[i], v)
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package mathgl
+
+import (
+	"errors"
+	"runtime"
+	"sync"
+)
+
+// MulOptions tunes the tiled, parallel matmul used by DenseF64.MulOpts and
+// DenseF32.MulOpts.
+type MulOptions struct {
+	// BlockSize is the edge length of the square C blocks the output is
+	// partitioned into. Each block is accumulated with an i,k,j inner
+	// kernel so the B operand is streamed row-wise. <= 0 uses
+	// DefaultMulOptions.BlockSize.
+	BlockSize int
+
+	// Parallelism caps how many blocks are computed concurrently. <= 0
+	// means sequential (no goroutines spawned).
+	Parallelism int
+}
+
+// DefaultMulOptions holds the default MulOpts tunables; its BlockSize is
+// used whenever opts.BlockSize <= 0. (DenseF64.Mul and DenseF32.Mul use the
+// recursive scheduler in recursivemul.go instead.) BlockSize is chosen to
+// keep an A-row/B-column pair of blocks resident in L1/L2 cache;
+// Parallelism follows the number of usable CPUs.
+var DefaultMulOptions = MulOptions{BlockSize: 64, Parallelism: runtime.GOMAXPROCS(0)}
+
+func resolveMulOptions(opts MulOptions) (blockSize, parallelism int) {
+	blockSize = opts.BlockSize
+	if blockSize <= 0 {
+		blockSize = DefaultMulOptions.BlockSize
+	}
+	parallelism = opts.Parallelism
+	if parallelism <= 0 {
+		parallelism = 1
+	}
+	return blockSize, parallelism
+}
+
+// MulOpts returns d * o as a new DenseF64, computed as a tiled matmul:
+// the output is partitioned into BlockSize x BlockSize blocks, each owned
+// by exactly one worker (so blocks never need synchronization on their
+// writes), and up to Parallelism blocks are computed at once by a worker
+// pool sized off opts.Parallelism (DefaultMulOptions.Parallelism follows
+// runtime.GOMAXPROCS). Within a block, the kernel accumulates
+// C_block += sum_k A_block(i,k) * B_block(k,j) iterating i,k,j so B is
+// streamed row-wise.
+func (d *DenseF64) MulOpts(o *DenseF64, opts MulOptions) (*DenseF64, error) {
+	if d.n != o.m {
+		return nil, errors.New("mathgl: inner dimensions do not match in DenseF64.Mul")
+	}
+	r := NewDenseF64(d.m, o.n)
+	blockSize, parallelism := resolveMulOptions(opts)
+
+	type block struct{ i0, j0 int }
+	var blocks []block
+	for i0 := 0; i0 < d.m; i0 += blockSize {
+		for j0 := 0; j0 < o.n; j0 += blockSize {
+			blocks = append(blocks, block{i0, j0})
+		}
+	}
+
+	work := func(b block) {
+		i1 := minInt(b.i0+blockSize, d.m)
+		j1 := minInt(b.j0+blockSize, o.n)
+		for k0 := 0; k0 < d.n; k0 += blockSize {
+			k1 := minInt(k0+blockSize, d.n)
+			mulBlockF64(d, o, r, b.i0, i1, b.j0, j1, k0, k1)
+		}
+	}
+
+	if parallelism <= 1 {
+		for _, b := range blocks {
+			work(b)
+		}
+		return r, nil
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, parallelism)
+	for _, b := range blocks {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(b block) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			work(b)
+		}(b)
+	}
+	wg.Wait()
+
+	return r, nil
+}
+
+// mulBlockF64 accumulates r[i0:i1, j0:j1] += a[i0:i1, k0:k1] * b[k0:k1, j0:j1]
+// by calling into Backend, so an accelerated BLAS (see blas.go) is actually
+// used by the blocked/recursive schedulers built on top of this, not just
+// reachable in theory.
+func mulBlockF64(a, b, r *DenseF64, i0, i1, j0, j1, k0, k1 int) {
+	m, n, k := i1-i0, j1-j0, k1-k0
+	if m <= 0 || n <= 0 || k <= 0 {
+		return
+	}
+	aSub := a.dat[i0*a.stride+k0:]
+	bSub := b.dat[k0*b.stride+j0:]
+	rSub := r.dat[i0*r.stride+j0:]
+	Backend.Dgemm(false, false, m, n, k, 1, aSub, a.stride, bSub, b.stride, 1, rSub, r.stride)
+}
+
+// MulOpts is the DenseF32 counterpart of (*DenseF64).MulOpts.
+func (d *DenseF32) MulOpts(o *DenseF32, opts MulOptions) (*DenseF32, error) {
+	if d.n != o.m {
+		return nil, errors.New("mathgl: inner dimensions do not match in DenseF32.Mul")
+	}
+	r := NewDenseF32(d.m, o.n)
+	blockSize, parallelism := resolveMulOptions(opts)
+
+	type block struct{ i0, j0 int }
+	var blocks []block
+	for i0 := 0; i0 < d.m; i0 += blockSize {
+		for j0 := 0; j0 < o.n; j0 += blockSize {
+			blocks = append(blocks, block{i0, j0})
+		}
+	}
+
+	work := func(b block) {
+		i1 := minInt(b.i0+blockSize, d.m)
+		j1 := minInt(b.j0+blockSize, o.n)
+		for k0 := 0; k0 < d.n; k0 += blockSize {
+			k1 := minInt(k0+blockSize, d.n)
+			mulBlockF32(d, o, r, b.i0, i1, b.j0, j1, k0, k1)
+		}
+	}
+
+	if parallelism <= 1 {
+		for _, b := range blocks {
+			work(b)
+		}
+		return r, nil
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, parallelism)
+	for _, b := range blocks {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(b block) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			work(b)
+		}(b)
+	}
+	wg.Wait()
+
+	return r, nil
+}
+
+// mulBlockF32 is the DenseF32 counterpart of mulBlockF64.
+func mulBlockF32(a, b, r *DenseF32, i0, i1, j0, j1, k0, k1 int) {
+	m, n, k := i1-i0, j1-j0, k1-k0
+	if m <= 0 || n <= 0 || k <= 0 {
+		return
+	}
+	aSub := a.dat[i0*a.stride+k0:]
+	bSub := b.dat[k0*b.stride+j0:]
+	rSub := r.dat[i0*r.stride+j0:]
+	Backend.Sgemm(false, false, m, n, k, 1, aSub, a.stride, bSub, b.stride, 1, rSub, r.stride)
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,80 @@
+package mathgl
+
+import (
+	"math"
+	"testing"
+)
+
+func naiveDenseF64Mul(a, b *DenseF64) *DenseF64 {
+	r := NewDenseF64(a.m, b.n)
+	for i := 0; i < a.m; i++ {
+		for j := 0; j < b.n; j++ {
+			sum := 0.0
+			for k := 0; k < a.n; k++ {
+				sum += a.At(i, k) * b.At(k, j)
+			}
+			r.Set(i, j, sum)
+		}
+	}
+	return r
+}
+
+func fillDenseF64(d *DenseF64, seed *float64) {
+	for i := range d.dat {
+		*seed += 0.37
+		d.dat[i] = math.Mod(*seed, 5) - 2
+	}
+}
+
+func assertDenseF64Equal(t *testing.T, got, want *DenseF64, label string) {
+	t.Helper()
+	m, n := want.Dims()
+	for i := 0; i < m; i++ {
+		for j := 0; j < n; j++ {
+			if math.Abs(got.At(i, j)-want.At(i, j)) > 1e-9 {
+				t.Fatalf("%s: [%d][%d] = %v, want %v", label, i, j, got.At(i, j), want.At(i, j))
+			}
+		}
+	}
+}
+
+func TestDenseF64MulOptsAgreesWithNaive(t *testing.T) {
+	cases := []struct {
+		name    string
+		m, k, n int
+		opts    MulOptions
+	}{
+		{"non-square, sequential", 17, 9, 23, MulOptions{BlockSize: 4, Parallelism: 1}},
+		{"non-square, parallel", 17, 9, 23, MulOptions{BlockSize: 4, Parallelism: 4}},
+		{"non-power-of-two, sequential", 37, 53, 29, MulOptions{BlockSize: 6, Parallelism: 1}},
+		{"non-power-of-two, parallel", 37, 53, 29, MulOptions{BlockSize: 6, Parallelism: 4}},
+		{"m < blockSize", 3, 40, 5, MulOptions{BlockSize: 8, Parallelism: 2}},
+		{"1x1x1", 1, 1, 1, MulOptions{BlockSize: 8, Parallelism: 2}},
+		{"default options", 65, 65, 65, MulOptions{}},
+	}
+
+	seed := 0.0
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			a := NewDenseF64(c.m, c.k)
+			b := NewDenseF64(c.k, c.n)
+			fillDenseF64(a, &seed)
+			fillDenseF64(b, &seed)
+
+			want := naiveDenseF64Mul(a, b)
+			got, err := a.MulOpts(b, c.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertDenseF64Equal(t, got, want, c.name)
+		})
+	}
+}
+
+func TestDenseF64MulOptsDimensionMismatch(t *testing.T) {
+	a := NewDenseF64(2, 3)
+	b := NewDenseF64(4, 5)
+	if _, err := a.MulOpts(b, DefaultMulOptions); err == nil {
+		t.Fatal("expected an error for mismatched inner dimensions, got nil")
+	}
+}
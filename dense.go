@@ -0,0 +1,241 @@
+package mathgl
+
+import "errors"
+
+// DenseF64 is a contiguous, row-major m x n matrix of float64, stored with
+// a configurable stride so it can also describe a sub-view of a larger
+// buffer. Unlike Matrix, which boxes every element as a VecNum, DenseF64
+// stores its data unboxed in a single []float64 and is the type Add, Sub
+// and Mul dispatch to when they can, since it lets the BLAS backend work
+// without per-element interface dispatch or allocation.
+type DenseF64 struct {
+	m, n, stride int
+	dat          []float64
+}
+
+// NewDenseF64 allocates a zeroed m x n dense matrix.
+func NewDenseF64(m, n int) *DenseF64 {
+	return &DenseF64{m: m, n: n, stride: n, dat: make([]float64, m*n)}
+}
+
+// DenseF64FromSlice wraps el (row-major, length m*n) as a DenseF64 without
+// copying. Changes to el are visible through the returned matrix.
+func DenseF64FromSlice(el []float64, m, n int) (*DenseF64, error) {
+	if len(el) != m*n {
+		return nil, errors.New("mathgl: data length does not match m*n")
+	}
+	return &DenseF64{m: m, n: n, stride: n, dat: el}, nil
+}
+
+// IdentityF64 returns the n x n identity matrix.
+func IdentityF64(n int) *DenseF64 {
+	d := NewDenseF64(n, n)
+	for i := 0; i < n; i++ {
+		d.Set(i, i, 1)
+	}
+	return d
+}
+
+func (d *DenseF64) Dims() (m, n int) { return d.m, d.n }
+
+func (d *DenseF64) At(i, j int) float64 { return d.dat[i*d.stride+j] }
+
+func (d *DenseF64) Set(i, j int, v float64) { d.dat[i*d.stride+j] = v }
+
+func (d *DenseF64) swapRows(r1, r2 int) {
+	for j := 0; j < d.n; j++ {
+		d.dat[r1*d.stride+j], d.dat[r2*d.stride+j] = d.dat[r2*d.stride+j], d.dat[r1*d.stride+j]
+	}
+}
+
+// Add returns d + o as a new DenseF64, computed a row at a time via
+// Backend.Daxpy so an accelerated Backend speeds this up too.
+func (d *DenseF64) Add(o *DenseF64) (*DenseF64, error) {
+	if d.m != o.m || d.n != o.n {
+		return nil, errors.New("mathgl: mismatched dimensions in DenseF64.Add")
+	}
+	r := NewDenseF64(d.m, d.n)
+	for i := 0; i < d.m; i++ {
+		copy(r.dat[i*r.stride:i*r.stride+d.n], d.dat[i*d.stride:i*d.stride+d.n])
+		Backend.Daxpy(d.n, 1, o.dat[i*o.stride:i*o.stride+d.n], 1, r.dat[i*r.stride:i*r.stride+d.n], 1)
+	}
+	return r, nil
+}
+
+// Sub returns d - o as a new DenseF64, computed a row at a time as
+// r = -o; r += d via Backend.Dscal and Backend.Daxpy.
+func (d *DenseF64) Sub(o *DenseF64) (*DenseF64, error) {
+	if d.m != o.m || d.n != o.n {
+		return nil, errors.New("mathgl: mismatched dimensions in DenseF64.Sub")
+	}
+	r := NewDenseF64(d.m, d.n)
+	for i := 0; i < d.m; i++ {
+		copy(r.dat[i*r.stride:i*r.stride+d.n], o.dat[i*o.stride:i*o.stride+d.n])
+		Backend.Dscal(d.n, -1, r.dat[i*r.stride:i*r.stride+d.n], 1)
+		Backend.Daxpy(d.n, 1, d.dat[i*d.stride:i*d.stride+d.n], 1, r.dat[i*r.stride:i*r.stride+d.n], 1)
+	}
+	return r, nil
+}
+
+// Mul returns d * o as a new DenseF64, computed with a fork/join recursive
+// divide-and-conquer matmul (see mulRecursiveF64, tuned via GrainSize). For
+// direct control over block size and the worker count instead, use MulOpts.
+func (d *DenseF64) Mul(o *DenseF64) (*DenseF64, error) {
+	if d.n != o.m {
+		return nil, errors.New("mathgl: inner dimensions do not match in DenseF64.Mul")
+	}
+	return mulRecursiveF64(d, o), nil
+}
+
+// DenseF32 is the float32 counterpart of DenseF64.
+type DenseF32 struct {
+	m, n, stride int
+	dat          []float32
+}
+
+// NewDenseF32 allocates a zeroed m x n dense matrix.
+func NewDenseF32(m, n int) *DenseF32 {
+	return &DenseF32{m: m, n: n, stride: n, dat: make([]float32, m*n)}
+}
+
+// DenseF32FromSlice wraps el (row-major, length m*n) as a DenseF32 without
+// copying. Changes to el are visible through the returned matrix.
+func DenseF32FromSlice(el []float32, m, n int) (*DenseF32, error) {
+	if len(el) != m*n {
+		return nil, errors.New("mathgl: data length does not match m*n")
+	}
+	return &DenseF32{m: m, n: n, stride: n, dat: el}, nil
+}
+
+func (d *DenseF32) Dims() (m, n int) { return d.m, d.n }
+
+func (d *DenseF32) At(i, j int) float32 { return d.dat[i*d.stride+j] }
+
+func (d *DenseF32) Set(i, j int, v float32) { d.dat[i*d.stride+j] = v }
+
+// Add returns d + o as a new DenseF32, computed a row at a time via
+// Backend.Saxpy so an accelerated Backend speeds this up too.
+func (d *DenseF32) Add(o *DenseF32) (*DenseF32, error) {
+	if d.m != o.m || d.n != o.n {
+		return nil, errors.New("mathgl: mismatched dimensions in DenseF32.Add")
+	}
+	r := NewDenseF32(d.m, d.n)
+	for i := 0; i < d.m; i++ {
+		copy(r.dat[i*r.stride:i*r.stride+d.n], d.dat[i*d.stride:i*d.stride+d.n])
+		Backend.Saxpy(d.n, 1, o.dat[i*o.stride:i*o.stride+d.n], 1, r.dat[i*r.stride:i*r.stride+d.n], 1)
+	}
+	return r, nil
+}
+
+// Sub returns d - o as a new DenseF32, computed a row at a time as
+// r = -o; r += d via Backend.Sscal and Backend.Saxpy.
+func (d *DenseF32) Sub(o *DenseF32) (*DenseF32, error) {
+	if d.m != o.m || d.n != o.n {
+		return nil, errors.New("mathgl: mismatched dimensions in DenseF32.Sub")
+	}
+	r := NewDenseF32(d.m, d.n)
+	for i := 0; i < d.m; i++ {
+		copy(r.dat[i*r.stride:i*r.stride+d.n], o.dat[i*o.stride:i*o.stride+d.n])
+		Backend.Sscal(d.n, -1, r.dat[i*r.stride:i*r.stride+d.n], 1)
+		Backend.Saxpy(d.n, 1, d.dat[i*d.stride:i*d.stride+d.n], 1, r.dat[i*r.stride:i*r.stride+d.n], 1)
+	}
+	return r, nil
+}
+
+// Mul returns d * o as a new DenseF32, computed with a fork/join recursive
+// divide-and-conquer matmul (see mulRecursiveF32, tuned via GrainSize). For
+// direct control over block size and the worker count instead, use MulOpts.
+func (d *DenseF32) Mul(o *DenseF32) (*DenseF32, error) {
+	if d.n != o.m {
+		return nil, errors.New("mathgl: inner dimensions do not match in DenseF32.Mul")
+	}
+	return mulRecursiveF32(d, o), nil
+}
+
+// denseF64Proto returns mat's first element as a float64Valuer, used both
+// as an "is this matrix dense-able" check and, on success, as the
+// prototype FromFloat64 boxes computed values back through.
+func denseF64Proto(mat Matrix) (float64Valuer, bool) {
+	if len(mat.dat) == 0 {
+		return nil, false
+	}
+	proto, ok := mat.dat[0].(float64Valuer)
+	return proto, ok
+}
+
+// denseF32Proto is the float32 counterpart of denseF64Proto.
+func denseF32Proto(mat Matrix) (float32Valuer, bool) {
+	if len(mat.dat) == 0 {
+		return nil, false
+	}
+	proto, ok := mat.dat[0].(float32Valuer)
+	return proto, ok
+}
+
+// AsDenseF64 tries to view mat as a DenseF64 without copying element boxes
+// through the generic VecNum path. It only succeeds when every element of
+// mat implements float64Valuer (the float64 VecNum backing does); callers
+// should fall back to the boxed interface-based path otherwise.
+func (mat Matrix) AsDenseF64() (*DenseF64, bool) {
+	dat := make([]float64, len(mat.dat))
+	for i, v := range mat.dat {
+		fv, ok := v.(float64Valuer)
+		if !ok {
+			return nil, false
+		}
+		dat[i] = fv.Float64()
+	}
+	return &DenseF64{m: mat.m, n: mat.n, stride: mat.n, dat: dat}, true
+}
+
+// AsDenseF32 is the float32 counterpart of AsDenseF64.
+func (mat Matrix) AsDenseF32() (*DenseF32, bool) {
+	dat := make([]float32, len(mat.dat))
+	for i, v := range mat.dat {
+		fv, ok := v.(float32Valuer)
+		if !ok {
+			return nil, false
+		}
+		dat[i] = fv.Float32()
+	}
+	return &DenseF32{m: mat.m, n: mat.n, stride: mat.n, dat: dat}, true
+}
+
+// float64Valuer is implemented by VecNum types that box a float64, letting
+// Matrix fast-path into DenseF64/BLAS without a type switch over every
+// concrete VecNum implementation. FromFloat64 is used to box the result of
+// a dense computation back into a VecNum of the same concrete type.
+type float64Valuer interface {
+	Float64() float64
+	FromFloat64(v float64) VecNum
+}
+
+// float32Valuer is the float32 counterpart of float64Valuer.
+type float32Valuer interface {
+	Float32() float32
+	FromFloat32(v float32) VecNum
+}
+
+// matrixFromDenseF64 boxes a DenseF64 computation result back into a Matrix
+// of typ, using proto (any VecNum of that type, e.g. an operand's own
+// element) to box each value back via FromFloat64.
+func matrixFromDenseF64(typ VecType, proto float64Valuer, d *DenseF64) (mat Matrix) {
+	mat.typ = typ
+	mat.m, mat.n = d.m, d.n
+	mat.dat = make([]VecNum, len(d.dat))
+	for i, v := range d.dat {
+		mat.dat[i] = proto.FromFloat64(v)
+	}
+	return mat
+}
+
+// matrixFromDenseF32 is the float32 counterpart of matrixFromDenseF64.
+func matrixFromDenseF32(typ VecType, proto float32Valuer, d *DenseF32) (mat Matrix) {
+	mat.typ = typ
+	mat.m, mat.n = d.m, d.n
+	mat.dat = make([]VecNum, len(d.dat))
+	for i, v := range d.dat {
+		mat.dat[i] = proto.FromFloat32(v)
+	}
+	return mat
+}
@@ -0,0 +1,192 @@
+package mathgl
+
+import (
+	"math"
+	"testing"
+)
+
+// testVecF64 is a minimal VecNum backed by float64, used to exercise the
+// Matrix -> AsDenseF64 -> matrixFromDenseF64 boxing round trip that
+// Matrix.Add/Sub/Mul dispatch through when every element implements
+// float64Valuer.
+type testVecF64 float64
+
+func (v testVecF64) add(o VecNum) VecNum          { return v + o.(testVecF64) }
+func (v testVecF64) sub(o VecNum) VecNum          { return v - o.(testVecF64) }
+func (v testVecF64) mul(o VecNum) VecNum          { return v * o.(testVecF64) }
+func (v testVecF64) Float64() float64             { return float64(v) }
+func (v testVecF64) FromFloat64(x float64) VecNum { return testVecF64(x) }
+
+// testVecF32 is the float32 counterpart of testVecF64, implementing
+// float32Valuer instead so it exercises Matrix's AsDenseF32 path rather
+// than AsDenseF64.
+type testVecF32 float32
+
+func (v testVecF32) add(o VecNum) VecNum          { return v + o.(testVecF32) }
+func (v testVecF32) sub(o VecNum) VecNum          { return v - o.(testVecF32) }
+func (v testVecF32) mul(o VecNum) VecNum          { return v * o.(testVecF32) }
+func (v testVecF32) Float32() float32             { return float32(v) }
+func (v testVecF32) FromFloat32(x float32) VecNum { return testVecF32(x) }
+
+func matrixOfF64(t *testing.T, m, n int, vals []float64) Matrix {
+	t.Helper()
+	dat := make([]VecNum, len(vals))
+	for i, v := range vals {
+		dat[i] = testVecF64(v)
+	}
+	mat, err := unsafeMatrixFromSlice(VecType(0), dat, m, n)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return *mat
+}
+
+func matrixOfF32(t *testing.T, m, n int, vals []float32) Matrix {
+	t.Helper()
+	dat := make([]VecNum, len(vals))
+	for i, v := range vals {
+		dat[i] = testVecF32(v)
+	}
+	mat, err := unsafeMatrixFromSlice(VecType(0), dat, m, n)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return *mat
+}
+
+func TestMatrixAddSubDispatchThroughDenseF64(t *testing.T) {
+	a := matrixOfF64(t, 2, 2, []float64{1, 2, 3, 4})
+	b := matrixOfF64(t, 2, 2, []float64{5, 6, 7, 8})
+
+	sum := a.Add(b)
+	wantSum := []float64{6, 8, 10, 12}
+	for i, want := range wantSum {
+		if got := float64(sum.dat[i].(testVecF64)); got != want {
+			t.Fatalf("sum.dat[%d] = %v, want %v", i, got, want)
+		}
+	}
+
+	diff := a.Sub(b)
+	wantDiff := []float64{-4, -4, -4, -4}
+	for i, want := range wantDiff {
+		if got := float64(diff.dat[i].(testVecF64)); got != want {
+			t.Fatalf("diff.dat[%d] = %v, want %v", i, got, want)
+		}
+	}
+}
+
+func TestMatrixMulDispatchThroughDenseF64(t *testing.T) {
+	a := matrixOfF64(t, 2, 3, []float64{1, 2, 3, 4, 5, 6})
+	b := matrixOfF64(t, 3, 2, []float64{7, 8, 9, 10, 11, 12})
+
+	prod := a.Mul(b)
+	want := []float64{58, 64, 139, 154}
+	for i, v := range want {
+		if got := float64(prod.dat[i].(testVecF64)); got != v {
+			t.Fatalf("prod.dat[%d] = %v, want %v", i, got, v)
+		}
+	}
+}
+
+func TestMatrixMulDispatchThroughDenseF32(t *testing.T) {
+	a := matrixOfF32(t, 2, 3, []float32{1, 2, 3, 4, 5, 6})
+	b := matrixOfF32(t, 3, 2, []float32{7, 8, 9, 10, 11, 12})
+
+	prod := a.Mul(b)
+	want := []float32{58, 64, 139, 154}
+	for i, v := range want {
+		if got := float32(prod.dat[i].(testVecF32)); got != v {
+			t.Fatalf("prod.dat[%d] = %v, want %v", i, got, v)
+		}
+	}
+}
+
+func TestDenseF64AddSub(t *testing.T) {
+	a := denseF64From(2, 2, [][]float64{{1, 2}, {3, 4}})
+	b := denseF64From(2, 2, [][]float64{{5, 6}, {7, 8}})
+
+	sum, err := a.Add(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertDenseF64Equal(t, sum, denseF64From(2, 2, [][]float64{{6, 8}, {10, 12}}), "Add")
+
+	diff, err := a.Sub(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertDenseF64Equal(t, diff, denseF64From(2, 2, [][]float64{{-4, -4}, {-4, -4}}), "Sub")
+}
+
+func naiveDenseF32Mul(a, b *DenseF32) *DenseF32 {
+	r := NewDenseF32(a.m, b.n)
+	for i := 0; i < a.m; i++ {
+		for j := 0; j < b.n; j++ {
+			var sum float32
+			for k := 0; k < a.n; k++ {
+				sum += a.At(i, k) * b.At(k, j)
+			}
+			r.Set(i, j, sum)
+		}
+	}
+	return r
+}
+
+func assertDenseF32Equal(t *testing.T, got, want *DenseF32, label string) {
+	t.Helper()
+	m, n := want.Dims()
+	for i := 0; i < m; i++ {
+		for j := 0; j < n; j++ {
+			if math.Abs(float64(got.At(i, j)-want.At(i, j))) > 1e-3 {
+				t.Fatalf("%s: [%d][%d] = %v, want %v", label, i, j, got.At(i, j), want.At(i, j))
+			}
+		}
+	}
+}
+
+func TestDenseF32MulAgreesWithNaive(t *testing.T) {
+	a := NewDenseF32(3, 2)
+	b := NewDenseF32(2, 4)
+	for i, v := range []float32{1, 2, 3, 4, 5, 6} {
+		a.dat[i] = v
+	}
+	for i, v := range []float32{1, 0, 2, 1, 0, 1, 1, 2} {
+		b.dat[i] = v
+	}
+
+	got, err := a.Mul(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertDenseF32Equal(t, got, naiveDenseF32Mul(a, b), "DenseF32.Mul")
+}
+
+func TestDenseF32AddSub(t *testing.T) {
+	a := NewDenseF32(2, 2)
+	b := NewDenseF32(2, 2)
+	for i, v := range []float32{1, 2, 3, 4} {
+		a.dat[i] = v
+	}
+	for i, v := range []float32{5, 6, 7, 8} {
+		b.dat[i] = v
+	}
+
+	sum, err := a.Add(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := NewDenseF32(2, 2)
+	for i, v := range []float32{6, 8, 10, 12} {
+		want.dat[i] = v
+	}
+	assertDenseF32Equal(t, sum, want, "Add")
+
+	diff, err := a.Sub(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, v := range []float32{-4, -4, -4, -4} {
+		want.dat[i] = v
+	}
+	assertDenseF32Equal(t, diff, want, "Sub")
+}
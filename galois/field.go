@@ -0,0 +1,68 @@
+// Package galois implements GF(2^8) byte arithmetic and byte matrices over
+// that field, the building block for Reed-Solomon / erasure coding style
+// use cases that don't fit the boxed VecNum path the rest of mathgl uses.
+package galois
+
+// primitivePoly is the standard GF(2^8) primitive polynomial, x^8 + x^4 +
+// x^3 + x^2 + 1, used by (among others) Reed-Solomon over GF(256).
+const primitivePoly = 0x1D
+
+// logTbl and expTbl are the discrete log/antilog tables used to turn
+// multiplication into addition: a*b = expTbl[(logTbl[a]+logTbl[b]) % 255],
+// with zero handled explicitly since log(0) is undefined. expTbl has 512
+// entries (the second half repeats the first), so Mul indexes it with the
+// unreduced sum logTbl[a]+logTbl[b] (at most 508) and never takes the mod.
+var (
+	logTbl [256]byte
+	expTbl [512]byte
+	// inverseTbl[a] is the multiplicative inverse of a in GF(256); inverseTbl[0] is unused.
+	inverseTbl [256]byte
+)
+
+func init() {
+	x := 1
+	for i := 0; i < 255; i++ {
+		expTbl[i] = byte(x)
+		logTbl[x] = byte(i)
+		x <<= 1
+		if x&0x100 != 0 {
+			x ^= 0x100 | primitivePoly
+		}
+	}
+	for i := 255; i < 512; i++ {
+		expTbl[i] = expTbl[i-255]
+	}
+
+	for a := 1; a < 256; a++ {
+		inverseTbl[a] = expTbl[255-int(logTbl[a])]
+	}
+}
+
+// Add returns a+b in GF(256), which is the same as subtraction: the field
+// has characteristic 2, so addition and subtraction are both XOR.
+func Add(a, b byte) byte { return a ^ b }
+
+// Sub returns a-b in GF(256). Identical to Add; provided for readability at
+// call sites that mean subtraction.
+func Sub(a, b byte) byte { return a ^ b }
+
+// Mul returns a*b in GF(256) via the log/antilog tables.
+func Mul(a, b byte) byte {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return expTbl[int(logTbl[a])+int(logTbl[b])]
+}
+
+// Inverse returns a^-1 in GF(256). a must be nonzero.
+func Inverse(a byte) byte {
+	return inverseTbl[a]
+}
+
+// Div returns a/b in GF(256). b must be nonzero.
+func Div(a, b byte) byte {
+	if a == 0 {
+		return 0
+	}
+	return Mul(a, Inverse(b))
+}
@@ -0,0 +1,104 @@
+package galois
+
+import "testing"
+
+func TestFieldArithmeticRoundTrips(t *testing.T) {
+	for a := 1; a < 256; a++ {
+		inv := Inverse(byte(a))
+		if got := Mul(byte(a), inv); got != 1 {
+			t.Fatalf("Mul(%d, Inverse(%d)=%d) = %d, want 1", a, a, inv, got)
+		}
+		if got := Div(byte(a), byte(a)); got != 1 {
+			t.Fatalf("Div(%d, %d) = %d, want 1", a, a, got)
+		}
+	}
+
+	for a := 0; a < 256; a++ {
+		for b := 0; b < 256; b++ {
+			if got := Mul(byte(a), byte(b)); got != Mul(byte(b), byte(a)) {
+				t.Fatalf("Mul(%d, %d) = %d is not commutative", a, b, got)
+			}
+		}
+	}
+
+	if Mul(0, 200) != 0 || Mul(200, 0) != 0 {
+		t.Fatal("Mul with a zero operand must be 0")
+	}
+	if Add(5, 5) != 0 {
+		t.Fatal("Add(a, a) must be 0 in a characteristic-2 field")
+	}
+}
+
+func TestMatrixMultiplyIdentity(t *testing.T) {
+	m, _ := MatrixFromSlice([]byte{1, 2, 3, 4, 5, 6}, 2, 3)
+	id := Identity(3)
+
+	prod, err := m.Multiply(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		for j := 0; j < 3; j++ {
+			if prod.At(i, j) != m.At(i, j) {
+				t.Fatalf("m*I[%d][%d] = %d, want %d", i, j, prod.At(i, j), m.At(i, j))
+			}
+		}
+	}
+}
+
+func TestMatrixInvertRoundTrips(t *testing.T) {
+	m, _ := MatrixFromSlice([]byte{1, 2, 3, 4, 5, 7, 8, 9, 11}, 3, 3)
+
+	inv, err := m.Invert()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prod, err := m.Multiply(inv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := Identity(3)
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			if prod.At(i, j) != id.At(i, j) {
+				t.Fatalf("m*m^-1[%d][%d] = %d, want %d", i, j, prod.At(i, j), id.At(i, j))
+			}
+		}
+	}
+}
+
+func TestMatrixInvertSingularReturnsError(t *testing.T) {
+	// Row 2 is Row 1 multiplied by 2 in GF(256) ({2,4,6} = 2*{1,2,3}), so
+	// this matrix is singular.
+	m, _ := MatrixFromSlice([]byte{1, 2, 3, 2, 4, 6, 5, 1, 9}, 3, 3)
+	if _, err := m.Invert(); err == nil {
+		t.Fatal("expected an error for a singular matrix, got nil")
+	}
+}
+
+func TestVandermondeSquareSubmatricesAreInvertible(t *testing.T) {
+	v := Vandermonde(8, 4)
+	for start := 0; start+4 <= 8; start++ {
+		sub, err := v.SubMatrix(start, start+4, 0, 4)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := sub.Invert(); err != nil {
+			t.Fatalf("Vandermonde square submatrix starting at row %d is singular: %v", start, err)
+		}
+	}
+}
+
+func TestCauchySquareSubmatricesAreInvertible(t *testing.T) {
+	c := Cauchy(8, 4)
+	for start := 0; start+4 <= 8; start++ {
+		sub, err := c.SubMatrix(start, start+4, 0, 4)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := sub.Invert(); err != nil {
+			t.Fatalf("Cauchy square submatrix starting at row %d is singular: %v", start, err)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package galois
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Matrix is an m x n matrix over GF(256), stored row-major in a single
+// []byte. It's the GF(2^8) analogue of mathgl.Matrix, but unboxed: each
+// element is a plain byte, since the field's only values are bytes.
+type Matrix struct {
+	m, n int
+	dat  []byte
+}
+
+// NewMatrix allocates a zeroed m x n matrix over GF(256).
+func NewMatrix(m, n int) *Matrix {
+	return &Matrix{m: m, n: n, dat: make([]byte, m*n)}
+}
+
+// MatrixFromSlice wraps el (row-major, length m*n) as a Matrix without
+// copying.
+func MatrixFromSlice(el []byte, m, n int) (*Matrix, error) {
+	if len(el) != m*n {
+		return nil, errors.New("galois: data length does not match m*n")
+	}
+	return &Matrix{m: m, n: n, dat: el}, nil
+}
+
+// Identity returns the n x n identity matrix over GF(256).
+func Identity(n int) *Matrix {
+	mat := NewMatrix(n, n)
+	for i := 0; i < n; i++ {
+		mat.Set(i, i, 1)
+	}
+	return mat
+}
+
+// Vandermonde returns the m x n Vandermonde matrix over GF(256), with
+// entry (i, j) = i^j (and 0^0 = 1). Any n of its rows form an invertible
+// n x n matrix (the evaluation points 0..m-1 are distinct for m <= 256),
+// which is what makes it usable as an erasure-code encode matrix; square
+// submatrices that drop columns can be singular. Unlike a systematic encode matrix it does not pass
+// data rows through unchanged on its own — the top rows are a genuine
+// Vandermonde block, not a permutation of the identity. Row-reduce the top
+// n rows to the identity first if you need a systematic code.
+func Vandermonde(m, n int) *Matrix {
+	mat := NewMatrix(m, n)
+	for i := 0; i < m; i++ {
+		x := byte(i)
+		pow := byte(1)
+		for j := 0; j < n; j++ {
+			mat.Set(i, j, pow)
+			pow = Mul(pow, x)
+		}
+	}
+	return mat
+}
+
+// Cauchy returns the m x n Cauchy matrix over GF(256) with entry
+// (i, j) = 1/(x_i ^ y_j), where x_i = byte(n+i) and y_j = byte(j) so the
+// x and y sets are disjoint (a Cauchy matrix requires x_i != y_j for all
+// i, j, and every square submatrix is guaranteed invertible). This makes
+// it a popular alternative to Vandermonde as an erasure-code encode
+// matrix, since it avoids Vandermonde's tendency to produce ill-conditioned
+// (here: singular) square submatrices for some shapes.
+func Cauchy(m, n int) *Matrix {
+	mat := NewMatrix(m, n)
+	for i := 0; i < m; i++ {
+		x := byte(n + i)
+		for j := 0; j < n; j++ {
+			y := byte(j)
+			mat.Set(i, j, Inverse(x^y))
+		}
+	}
+	return mat
+}
+
+func (mat *Matrix) Dims() (m, n int) { return mat.m, mat.n }
+
+func (mat *Matrix) At(i, j int) byte { return mat.dat[i*mat.n+j] }
+
+func (mat *Matrix) Set(i, j int, v byte) { mat.dat[i*mat.n+j] = v }
+
+// SubMatrix returns a copy of the rows [i0, i1) and columns [j0, j1) of mat.
+func (mat *Matrix) SubMatrix(i0, i1, j0, j1 int) (*Matrix, error) {
+	if i0 < 0 || j0 < 0 || i1 > mat.m || j1 > mat.n || i0 >= i1 || j0 >= j1 {
+		return nil, fmt.Errorf("galois: SubMatrix(%d, %d, %d, %d) out of bounds for %dx%d matrix", i0, i1, j0, j1, mat.m, mat.n)
+	}
+	sub := NewMatrix(i1-i0, j1-j0)
+	for i := i0; i < i1; i++ {
+		for j := j0; j < j1; j++ {
+			sub.Set(i-i0, j-j0, mat.At(i, j))
+		}
+	}
+	return sub, nil
+}
+
+// Multiply returns mat * o.
+func (mat *Matrix) Multiply(o *Matrix) (*Matrix, error) {
+	if mat.n != o.m {
+		return nil, fmt.Errorf("galois: cannot multiply %dx%d by %dx%d", mat.m, mat.n, o.m, o.n)
+	}
+	r := NewMatrix(mat.m, o.n)
+	for i := 0; i < mat.m; i++ {
+		for k := 0; k < mat.n; k++ {
+			aik := mat.At(i, k)
+			if aik == 0 {
+				continue
+			}
+			for j := 0; j < o.n; j++ {
+				r.Set(i, j, r.At(i, j)^Mul(aik, o.At(k, j)))
+			}
+		}
+	}
+	return r, nil
+}
+
+// Invert returns the inverse of mat via Gauss-Jordan elimination over
+// GF(256): augment mat with the identity as [mat | I], then for each
+// column find a nonzero pivot row (swapping rows if needed), scale the
+// pivot row by the field inverse of the pivot, and XOR-eliminate that
+// column out of every other row using the scaled pivot row. Once every
+// column has been eliminated this way the left half is the identity and
+// the right half is mat^-1. A singular matrix (no nonzero pivot for some
+// column) returns an error.
+func (mat *Matrix) Invert() (*Matrix, error) {
+	if mat.m != mat.n {
+		return nil, errors.New("galois: Invert requires a square matrix")
+	}
+	n := mat.n
+
+	aug := NewMatrix(n, 2*n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			aug.Set(i, j, mat.At(i, j))
+		}
+		aug.Set(i, n+i, 1)
+	}
+
+	for col := 0; col < n; col++ {
+		pivot := -1
+		for row := col; row < n; row++ {
+			if aug.At(row, col) != 0 {
+				pivot = row
+				break
+			}
+		}
+		if pivot == -1 {
+			return nil, errors.New("galois: matrix is singular, cannot invert")
+		}
+		if pivot != col {
+			aug.swapRows(pivot, col)
+		}
+
+		inv := Inverse(aug.At(col, col))
+		for j := 0; j < 2*n; j++ {
+			aug.Set(col, j, Mul(aug.At(col, j), inv))
+		}
+
+		for row := 0; row < n; row++ {
+			if row == col {
+				continue
+			}
+			factor := aug.At(row, col)
+			if factor == 0 {
+				continue
+			}
+			for j := 0; j < 2*n; j++ {
+				aug.Set(row, j, aug.At(row, j)^Mul(factor, aug.At(col, j)))
+			}
+		}
+	}
+
+	return aug.SubMatrix(0, n, n, 2*n)
+}
+
+func (mat *Matrix) swapRows(r1, r2 int) {
+	for j := 0; j < mat.n; j++ {
+		mat.dat[r1*mat.n+j], mat.dat[r2*mat.n+j] = mat.dat[r2*mat.n+j], mat.dat[r1*mat.n+j]
+	}
+}
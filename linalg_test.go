@@ -0,0 +1,190 @@
+package mathgl
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func denseF64From(m, n int, rows [][]float64) *DenseF64 {
+	d := NewDenseF64(m, n)
+	for i := 0; i < m; i++ {
+		for j := 0; j < n; j++ {
+			d.Set(i, j, rows[i][j])
+		}
+	}
+	return d
+}
+
+func TestLUDetMatchesKnownValue(t *testing.T) {
+	a := denseF64From(3, 3, [][]float64{
+		{6, 1, 1},
+		{4, -2, 5},
+		{2, 8, 7},
+	})
+	det, err := a.Det()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(det-(-306)) > 1e-9 {
+		t.Fatalf("Det() = %v, want -306", det)
+	}
+}
+
+func TestLUInverseRoundTrips(t *testing.T) {
+	a := denseF64From(3, 3, [][]float64{
+		{4, 3, 2},
+		{1, 5, 6},
+		{7, 8, 9},
+	})
+	inv, err := a.Inverse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	prod, err := a.Mul(inv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := IdentityF64(3)
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			if math.Abs(prod.At(i, j)-want.At(i, j)) > 1e-9 {
+				t.Fatalf("A*A^-1[%d][%d] = %v, want %v", i, j, prod.At(i, j), want.At(i, j))
+			}
+		}
+	}
+}
+
+func TestLUSolveMatchesKnownSolution(t *testing.T) {
+	a := denseF64From(3, 3, [][]float64{
+		{2, 1, 1},
+		{1, 3, 2},
+		{1, 0, 0},
+	})
+	b := denseF64From(3, 1, [][]float64{{4}, {5}, {6}})
+
+	f, err := a.LU()
+	if err != nil {
+		t.Fatal(err)
+	}
+	x, err := f.Solve(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// a*x should reproduce b.
+	ax, err := a.Mul(x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		if math.Abs(ax.At(i, 0)-b.At(i, 0)) > 1e-9 {
+			t.Fatalf("A*x[%d] = %v, want %v", i, ax.At(i, 0), b.At(i, 0))
+		}
+	}
+}
+
+func TestLUSolveDimensionMismatchReturnsError(t *testing.T) {
+	a := denseF64From(3, 3, [][]float64{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}})
+	b := NewDenseF64(2, 1)
+
+	f, err := a.LU()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = f.Solve(b)
+	if err == nil {
+		t.Fatal("expected an error for mismatched b dimensions, got nil")
+	}
+	var sme *SingularMatrixError
+	if !errors.As(err, &sme) {
+		t.Fatalf("expected a *SingularMatrixError, got %T: %v", err, err)
+	}
+}
+
+func TestLUSingularReturnsSingularMatrixError(t *testing.T) {
+	a := denseF64From(2, 2, [][]float64{{1, 2}, {2, 4}})
+	_, err := a.LU()
+	if err == nil {
+		t.Fatal("expected an error for a singular matrix, got nil")
+	}
+	var sme *SingularMatrixError
+	if !errors.As(err, &sme) {
+		t.Fatalf("expected a *SingularMatrixError, got %T: %v", err, err)
+	}
+}
+
+func TestQRExplicitReproducesA(t *testing.T) {
+	a := denseF64From(5, 3, [][]float64{
+		{1, 2, 3},
+		{4, 5, 6},
+		{7, 8, 10},
+		{1, 0, 1},
+		{2, 1, 0},
+	})
+	f, err := a.QR()
+	if err != nil {
+		t.Fatal(err)
+	}
+	q, r := f.Explicit()
+	qr, err := q.Mul(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 5; i++ {
+		for j := 0; j < 3; j++ {
+			if math.Abs(qr.At(i, j)-a.At(i, j)) > 1e-9 {
+				t.Fatalf("Q*R[%d][%d] = %v, want %v", i, j, qr.At(i, j), a.At(i, j))
+			}
+		}
+	}
+}
+
+func TestQRSolveMatchesLeastSquaresSolution(t *testing.T) {
+	// An overdetermined, exactly-consistent system: b = a*{1,1,1}.
+	a := denseF64From(5, 3, [][]float64{
+		{1, 2, 3},
+		{4, 5, 6},
+		{7, 8, 10},
+		{1, 0, 1},
+		{2, 1, 0},
+	})
+	x := denseF64From(3, 1, [][]float64{{1}, {1}, {1}})
+	b, err := a.Mul(x)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := a.Solve(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		if math.Abs(got.At(i, 0)-1) > 1e-6 {
+			t.Fatalf("Solve()[%d] = %v, want 1", i, got.At(i, 0))
+		}
+	}
+}
+
+// TestQRSolveDimensionMismatchReturnsError is a regression test: Solve used
+// to index into b assuming b.m == f.m, panicking with an index-out-of-range
+// instead of reporting the mismatch for a non-square A.
+func TestQRSolveDimensionMismatchReturnsError(t *testing.T) {
+	a := denseF64From(5, 3, [][]float64{
+		{1, 2, 3},
+		{4, 5, 6},
+		{7, 8, 10},
+		{1, 0, 1},
+		{2, 1, 0},
+	})
+	b := NewDenseF64(3, 1) // should be 5x1
+
+	_, err := a.Solve(b)
+	if err == nil {
+		t.Fatal("expected an error for mismatched b dimensions, got nil")
+	}
+	var sme *SingularMatrixError
+	if !errors.As(err, &sme) {
+		t.Fatalf("expected a *SingularMatrixError, got %T: %v", err, err)
+	}
+}
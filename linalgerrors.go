@@ -0,0 +1,36 @@
+package mathgl
+
+import "fmt"
+
+// PivotTolerance is the minimum pivot magnitude LU, Det, Inverse and Solve
+// will accept before treating a matrix as singular (for a QR-based Solve,
+// the minimum magnitude of R's diagonal; QR itself does not check it). Lower it if
+// you're intentionally working with near-singular matrices and want a
+// best-effort factorization instead of an error.
+var PivotTolerance = 1e-12
+
+// SingularMatrixError is returned by Det, Inverse, LU and Solve when
+// factorization hits a column with no pivot candidate above PivotTolerance.
+// It is also returned, with Column -1, when the operands' shapes don't fit:
+// a non-square matrix passed to LU, Det or Inverse, a b whose row count
+// doesn't match A in Solve, or a QR-based Solve with fewer rows than columns.
+type SingularMatrixError struct {
+	Column    int
+	Tolerance float64
+}
+
+func (e *SingularMatrixError) Error() string {
+	return fmt.Sprintf("mathgl: matrix is singular: no pivot above tolerance %g at column %d", e.Tolerance, e.Column)
+}
+
+// UnsupportedTypeError is returned by the Matrix-level Det, Inverse, LU, QR
+// and Solve wrappers when mat isn't backed by a float64 VecNum: those
+// operations need division and are implemented against DenseF64 rather
+// than the generic boxed VecNum interface.
+type UnsupportedTypeError struct {
+	Op string
+}
+
+func (e *UnsupportedTypeError) Error() string {
+	return fmt.Sprintf("mathgl: Matrix.%s is only implemented for float64-backed matrices", e.Op)
+}
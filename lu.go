@@ -0,0 +1,131 @@
+package mathgl
+
+import "math"
+
+// LUDenseF64 is an LU decomposition of a square DenseF64 with partial
+// pivoting: A = P^-1 * L * U, where L is unit lower triangular, U is
+// upper triangular, and the row permutation P is recorded in piv (piv[i]
+// is the index of the original row now at row i of lu). L and U are
+// packed into a single matrix: the strictly-lower part (with an implicit
+// unit diagonal) is L, the diagonal and upper part is U.
+type LUDenseF64 struct {
+	lu   *DenseF64
+	piv  []int
+	sign float64 // +1 or -1, the parity of the row swaps performed
+}
+
+// LU factors d via Gaussian elimination with partial pivoting. d must be
+// square; a pivot column with every candidate magnitude below
+// PivotTolerance returns a *SingularMatrixError.
+func (d *DenseF64) LU() (*LUDenseF64, error) {
+	n := d.m
+	if d.m != d.n {
+		return nil, &SingularMatrixError{Column: -1, Tolerance: PivotTolerance}
+	}
+
+	lu := NewDenseF64(n, n)
+	copy(lu.dat, d.dat)
+
+	piv := make([]int, n)
+	for i := range piv {
+		piv[i] = i
+	}
+	sign := 1.0
+
+	for col := 0; col < n; col++ {
+		pivotRow, pivotMag := col, math.Abs(lu.At(col, col))
+		for row := col + 1; row < n; row++ {
+			if mag := math.Abs(lu.At(row, col)); mag > pivotMag {
+				pivotRow, pivotMag = row, mag
+			}
+		}
+		if pivotMag < PivotTolerance {
+			return nil, &SingularMatrixError{Column: col, Tolerance: PivotTolerance}
+		}
+		if pivotRow != col {
+			lu.swapRows(pivotRow, col)
+			piv[pivotRow], piv[col] = piv[col], piv[pivotRow]
+			sign = -sign
+		}
+
+		pivotVal := lu.At(col, col)
+		for row := col + 1; row < n; row++ {
+			factor := lu.At(row, col) / pivotVal
+			lu.Set(row, col, factor)
+			for j := col + 1; j < n; j++ {
+				lu.Set(row, j, lu.At(row, j)-factor*lu.At(col, j))
+			}
+		}
+	}
+
+	return &LUDenseF64{lu: lu, piv: piv, sign: sign}, nil
+}
+
+// Det returns det(A) = (-1)^swaps * prod(U[i,i]).
+func (f *LUDenseF64) Det() float64 {
+	det := f.sign
+	for i := range f.piv {
+		det *= f.lu.At(i, i)
+	}
+	return det
+}
+
+// Solve solves A*x = b for x, where A is the matrix this decomposition
+// factored. b and the returned x are n x r for any number of columns r.
+func (f *LUDenseF64) Solve(b *DenseF64) (*DenseF64, error) {
+	n := len(f.piv)
+	if b.m != n {
+		return nil, &SingularMatrixError{Column: -1, Tolerance: PivotTolerance}
+	}
+
+	x := NewDenseF64(n, b.n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < b.n; j++ {
+			x.Set(i, j, b.At(f.piv[i], j))
+		}
+	}
+
+	for col := 0; col < b.n; col++ {
+		// Forward substitution: L y = P b (L unit lower triangular).
+		for i := 1; i < n; i++ {
+			sum := x.At(i, col)
+			for k := 0; k < i; k++ {
+				sum -= f.lu.At(i, k) * x.At(k, col)
+			}
+			x.Set(i, col, sum)
+		}
+		// Back substitution: U x = y.
+		for i := n - 1; i >= 0; i-- {
+			sum := x.At(i, col)
+			for k := i + 1; k < n; k++ {
+				sum -= f.lu.At(i, k) * x.At(k, col)
+			}
+			x.Set(i, col, sum/f.lu.At(i, i))
+		}
+	}
+
+	return x, nil
+}
+
+// Inverse returns A^-1 by solving A*X = I one unit column at a time.
+func (f *LUDenseF64) Inverse() (*DenseF64, error) {
+	return f.Solve(IdentityF64(len(f.piv)))
+}
+
+// Det returns det(d) via an LU decomposition with partial pivoting.
+func (d *DenseF64) Det() (float64, error) {
+	f, err := d.LU()
+	if err != nil {
+		return 0, err
+	}
+	return f.Det(), nil
+}
+
+// Inverse returns d^-1 via an LU decomposition with partial pivoting.
+func (d *DenseF64) Inverse() (*DenseF64, error) {
+	f, err := d.LU()
+	if err != nil {
+		return nil, err
+	}
+	return f.Inverse()
+}
@@ -20,6 +20,7 @@ func NewMatrix(m, n int, typ VecType) *Matrix {
 // This may seem confusing, but it's because it's easier to type out and visualize things in CMO
 // So it's easier to type write your matrix as a slice in CMO, and pass it into this method
 func MatrixFromCols(typ VecType, el [][]VecNum) (mat *Matrix, err error) {
+	mat = &Matrix{}
 	mat.typ = typ
 
 	mat.m = len(el)
@@ -41,6 +42,7 @@ func MatrixFromCols(typ VecType, el [][]VecNum) (mat *Matrix, err error) {
 
 // This function is MatrixOf, except it takes a list of row "vectors" instead of row "vectors" (really slices)
 func MatrixFromRows(typ VecType, el [][]VecNum) (mat *Matrix, err error) {
+	mat = &Matrix{}
 	mat.typ = typ
 
 	mat.m = len(el)
@@ -62,6 +64,7 @@ func MatrixFromRows(typ VecType, el [][]VecNum) (mat *Matrix, err error) {
 
 // Slice-format data should be in Row Major Order
 func MatrixFromSlice(typ VecType, el []VecNum, m, n int) (mat *Matrix, err error) {
+	mat = &Matrix{}
 	mat.typ = typ
 	mat.m = m
 	mat.n = n
@@ -83,6 +86,7 @@ func MatrixFromSlice(typ VecType, el []VecNum, m, n int) (mat *Matrix, err error
 
 // Quick and dirty internal function to make a matrix without spending time checking types
 func unsafeMatrixFromSlice(typ VecType, el []VecNum, m, n int) (mat *Matrix, err error) {
+	mat = &Matrix{}
 	mat.typ = typ
 	mat.m = m
 	mat.n = n
@@ -134,6 +138,16 @@ func (m1 Matrix) Add(m2 Matrix) (m3 Matrix) {
 		return
 	}
 
+	if proto, ok := denseF64Proto(m1); ok {
+		if d1, ok1 := m1.AsDenseF64(); ok1 {
+			if d2, ok2 := m2.AsDenseF64(); ok2 {
+				if sum, err := d1.Add(d2); err == nil {
+					return matrixFromDenseF64(m1.typ, proto, sum)
+				}
+			}
+		}
+	}
+
 	m3.typ = m1.typ
 	m3.dat = make([]VecNum, len(m1.dat))
 
@@ -149,6 +163,16 @@ func (m1 Matrix) Sub(m2 Matrix) (m3 Matrix) {
 		return
 	}
 
+	if proto, ok := denseF64Proto(m1); ok {
+		if d1, ok1 := m1.AsDenseF64(); ok1 {
+			if d2, ok2 := m2.AsDenseF64(); ok2 {
+				if diff, err := d1.Sub(d2); err == nil {
+					return matrixFromDenseF64(m1.typ, proto, diff)
+				}
+			}
+		}
+	}
+
 	m3.typ = m1.typ
 	m3.dat = make([]VecNum, len(m1.dat))
 
@@ -159,17 +183,48 @@ func (m1 Matrix) Sub(m2 Matrix) (m3 Matrix) {
 	return m3
 }
 
+// Mul multiplies m1 by m2. When both operands are backed by a float64 (or
+// float32) VecNum and so can be viewed as a dense, unboxed matrix, it
+// dispatches to DenseF64.Mul/DenseF32.Mul (which schedule tiled, parallel
+// work down to leaf blocks computed via Backend.Dgemm/Sgemm); this avoids
+// the per-element interface dispatch and allocation the boxed loop below
+// incurs. Any other VecNum type falls back to that loop.
 func (m1 Matrix) Mul(m2 Matrix) (m3 Matrix) {
 	if m1.n != m2.m || m1.typ != m2.typ {
 		return
 	}
+
+	if proto, ok := denseF64Proto(m1); ok {
+		if d1, ok1 := m1.AsDenseF64(); ok1 {
+			if d2, ok2 := m2.AsDenseF64(); ok2 {
+				if prod, err := d1.Mul(d2); err == nil {
+					return matrixFromDenseF64(m1.typ, proto, prod)
+				}
+			}
+		}
+	}
+	if proto, ok := denseF32Proto(m1); ok {
+		if d1, ok1 := m1.AsDenseF32(); ok1 {
+			if d2, ok2 := m2.AsDenseF32(); ok2 {
+				if prod, err := d1.Mul(d2); err == nil {
+					return matrixFromDenseF32(m1.typ, proto, prod)
+				}
+			}
+		}
+	}
+
 	dat := make([]VecNum, m1.m*m2.n)
 
-	for j := 0; j < m2.n; j++ { // Columns of m2 and m3
-		for i := 0; i < m1.m; i++ { // Rows of m1 and m3
-			for k := 0; k < m1.n; k++ { // Columns of m1, rows of m2
-				dat[j*m2.n+i] = dat[j*m2.n+i].add(m1.dat[k*m1.n+i].mul(m2.dat[j*m2.n+k])) // I think, needs testing
+	for i := 0; i < m1.m; i++ { // Rows of m1 and m3
+		for j := 0; j < m2.n; j++ { // Columns of m2 and m3
+			// Seed the accumulator with the k=0 product instead of
+			// .add()-ing into it: dat[i*m2.n+j] starts out nil, and nil
+			// has no .add method to call.
+			acc := m1.dat[i*m1.n].mul(m2.dat[j])
+			for k := 1; k < m1.n; k++ { // Columns of m1, rows of m2
+				acc = acc.add(m1.dat[i*m1.n+k].mul(m2.dat[k*m2.n+j]))
 			}
+			dat[i*m2.n+j] = acc
 		}
 	}
 
@@ -182,51 +237,141 @@ func (m1 Matrix) Mul(m2 Matrix) (m3 Matrix) {
 }
 
 
-/* 
-Batch Multiply, as its name implies. Is supposed to multiply a huge amount of matrices at once
-Since matrix multiplication is associative, it can do pieces of the problem at the same time.
-Since starting a goroutine has some overhead, I'd wager it's probably not worth it to use this function unless you have
-6-8+ matrices, but I haven't benchmarked it so until then who knows?
-
-Make sure the matrices are in order, and that they can indeed be multiplied. If not you'll end up with an untyped 0x0 matrix (a.k.a the "zero type" for a Matrix struct)
-*/
+// Batch Multiply, as its name implies, multiplies a chain of matrices at
+// once by folding Mul across them left to right.
+//
+// This used to split args in half and run each half on its own goroutine,
+// on the theory that matrix mult is associative so the halves can be
+// computed independently. That parallelizes across the (cheap) reduction
+// over matrices instead of within each (expensive) multiply, which starves
+// the tiled/parallel fast path inside Mul of any matrix big enough for it
+// to matter. Folding sequentially lets every Mul in the chain use its own
+// block-parallel scheduler instead.
+//
+// Make sure the matrices are in order, and that they can indeed be multiplied. If not you'll end up with an untyped 0x0 matrix (a.k.a the "zero type" for a Matrix struct)
 func BatchMultiply(args []Matrix) Matrix {
-	if len(args) == 1 {
-		return args[0]
-	}
-	
-	var m1,m2 Matrix
-	if len(args) > 2 {
-			ch1 := make(chan Matrix)
-			ch2 := make(chan Matrix)
-			
-			// Split up the work, matrix mult is associative
-			go batchMultHelper(ch1, args[0:len(args)/2])
-			go batchMultHelper(ch2, args[len(args)/2:len(args)])
-			
-			m1 = <- ch1
-			m2 = <- ch2
-	} else {
-		m1 = args[0]
-		m2 = args[1]
-	}
-	
-	
-	return m1.Mul(m2)
-}
-
-// Wrapper so we can use multiply concurrently. Code duplication might be faster (if concurrency is faster here at all, that is). We'll need benchmarks to be sure
-func batchMultHelper(ch chan<- Matrix, args[]Matrix) { 
-	ch <- BatchMultiply(args)
-	close(ch)
-}
-
-// INCOMPLETE DO NOT USE
-// Need better function to make matrices for recursion
-func (m1 Matrix) Det() interface{} {
+	if len(args) == 0 {
+		var m3 Matrix
+		return m3
+	}
+
+	m3 := args[0]
+	for _, m := range args[1:] {
+		m3 = m3.Mul(m)
+	}
+
+	return m3
+}
+
+// Det returns the determinant of m1 via an LU decomposition with partial
+// pivoting. m1 must be square and float64-backed; anything else returns an
+// error (*SingularMatrixError or *UnsupportedTypeError) instead of nil.
+func (m1 Matrix) Det() (VecNum, error) {
 	if m1.m != m1.n { // Determinants are only for square matrices
-		return nil
+		return nil, &SingularMatrixError{Column: -1, Tolerance: PivotTolerance}
 	}
 
-	return nil
+	proto, ok := denseF64Proto(m1)
+	if !ok {
+		return nil, &UnsupportedTypeError{Op: "Det"}
+	}
+	d, _ := m1.AsDenseF64()
+
+	det, err := d.Det()
+	if err != nil {
+		return nil, err
+	}
+
+	return proto.FromFloat64(det), nil
+}
+
+// Inverse returns m1^-1 via an LU decomposition with partial pivoting. m1
+// must be square and float64-backed.
+func (m1 Matrix) Inverse() (Matrix, error) {
+	proto, ok := denseF64Proto(m1)
+	if !ok {
+		return Matrix{}, &UnsupportedTypeError{Op: "Inverse"}
+	}
+	d, _ := m1.AsDenseF64()
+
+	inv, err := d.Inverse()
+	if err != nil {
+		return Matrix{}, err
+	}
+
+	return matrixFromDenseF64(m1.typ, proto, inv), nil
+}
+
+// LU returns m1's LU decomposition as boxed, unit-lower-triangular l and
+// upper-triangular u matrices, plus the row permutation applied during
+// partial pivoting: piv[i] is the index of m1's original row now at row i
+// of l and u. m1 must be square and float64-backed.
+func (m1 Matrix) LU() (l, u Matrix, piv []int, err error) {
+	proto, ok := denseF64Proto(m1)
+	if !ok {
+		return Matrix{}, Matrix{}, nil, &UnsupportedTypeError{Op: "LU"}
+	}
+	d, _ := m1.AsDenseF64()
+
+	f, err := d.LU()
+	if err != nil {
+		return Matrix{}, Matrix{}, nil, err
+	}
+
+	n := m1.m
+	ld, ud := NewDenseF64(n, n), NewDenseF64(n, n)
+	for i := 0; i < n; i++ {
+		ld.Set(i, i, 1)
+		for j := 0; j < n; j++ {
+			if j < i {
+				ld.Set(i, j, f.lu.At(i, j))
+			} else {
+				ud.Set(i, j, f.lu.At(i, j))
+			}
+		}
+	}
+
+	return matrixFromDenseF64(m1.typ, proto, ld), matrixFromDenseF64(m1.typ, proto, ud), f.piv, nil
+}
+
+// QR returns m1's QR decomposition as boxed, orthogonal q and
+// upper-triangular r matrices, computed via Householder reflections. m1
+// must be float64-backed.
+func (m1 Matrix) QR() (q, r Matrix, err error) {
+	proto, ok := denseF64Proto(m1)
+	if !ok {
+		return Matrix{}, Matrix{}, &UnsupportedTypeError{Op: "QR"}
+	}
+	d, _ := m1.AsDenseF64()
+
+	f, err := d.QR()
+	if err != nil {
+		return Matrix{}, Matrix{}, err
+	}
+
+	qd, rd := f.Explicit()
+	return matrixFromDenseF64(m1.typ, proto, qd), matrixFromDenseF64(m1.typ, proto, rd), nil
+}
+
+// Solve solves m1*x = b, dispatching to an LU solve when m1 is square and
+// to a Householder QR least-squares solve otherwise. m1 and b must be
+// float64-backed.
+func (m1 Matrix) Solve(b Matrix) (x Matrix, err error) {
+	proto, ok := denseF64Proto(m1)
+	if !ok {
+		return Matrix{}, &UnsupportedTypeError{Op: "Solve"}
+	}
+	if _, ok := denseF64Proto(b); !ok {
+		return Matrix{}, &UnsupportedTypeError{Op: "Solve"}
+	}
+
+	ad, _ := m1.AsDenseF64()
+	bd, _ := b.AsDenseF64()
+
+	xd, err := ad.Solve(bd)
+	if err != nil {
+		return Matrix{}, err
+	}
+
+	return matrixFromDenseF64(m1.typ, proto, xd), nil
 }
@@ -0,0 +1,46 @@
+package mathgl
+
+import "testing"
+
+// testVecBoxed is a VecNum that implements neither float64Valuer nor
+// float32Valuer, so Matrix.Mul can't take the DenseF64/DenseF32 fast path
+// and must fall back to the boxed, interface-dispatched loop.
+type testVecBoxed int
+
+func (v testVecBoxed) add(o VecNum) VecNum { return v + o.(testVecBoxed) }
+func (v testVecBoxed) sub(o VecNum) VecNum { return v - o.(testVecBoxed) }
+func (v testVecBoxed) mul(o VecNum) VecNum { return v * o.(testVecBoxed) }
+
+func matrixOfBoxed(t *testing.T, m, n int, vals []int) Matrix {
+	t.Helper()
+	dat := make([]VecNum, len(vals))
+	for i, v := range vals {
+		dat[i] = testVecBoxed(v)
+	}
+	return Matrix{typ: VecType(0), m: m, n: n, dat: dat}
+}
+
+func TestMatrixMulBoxedFallback(t *testing.T) {
+	a := matrixOfBoxed(t, 2, 3, []int{1, 2, 3, 4, 5, 6})
+	b := matrixOfBoxed(t, 3, 2, []int{7, 8, 9, 10, 11, 12})
+
+	prod := a.Mul(b)
+	want := []int{58, 64, 139, 154}
+	for i, v := range want {
+		if got := int(prod.dat[i].(testVecBoxed)); got != v {
+			t.Fatalf("prod.dat[%d] = %v, want %v", i, got, v)
+		}
+	}
+}
+
+func TestMatrixMulBoxedFallbackSingleTerm(t *testing.T) {
+	// A degenerate k=1 product exercises the accumulator seeding path
+	// (acc := m1.dat[i*m1.n].mul(...)) with no further .add calls.
+	a := matrixOfBoxed(t, 1, 1, []int{6})
+	b := matrixOfBoxed(t, 1, 1, []int{7})
+
+	prod := a.Mul(b)
+	if got := int(prod.dat[0].(testVecBoxed)); got != 42 {
+		t.Fatalf("prod.dat[0] = %v, want 42", got)
+	}
+}
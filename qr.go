@@ -0,0 +1,179 @@
+package mathgl
+
+import "math"
+
+// QRDenseF64 is a QR decomposition of an m x n DenseF64 (m >= n) computed
+// via Householder reflections, stored compactly: qr's diagonal and upper
+// triangle hold R, and column col's Householder vector (with an implicit
+// leading 1) is stored below the diagonal in that same column; tau[col]
+// is the corresponding reflector scale, so that Q = H_0 * H_1 * ... *
+// H_{k-1} with H_col = I - tau[col] * v * v^T.
+type QRDenseF64 struct {
+	qr   *DenseF64
+	tau  []float64
+	m, n int
+}
+
+// QR factors d via Householder reflections. d need not be square, but
+// does need m >= n for Solve's least-squares back-substitution to apply.
+func (d *DenseF64) QR() (*QRDenseF64, error) {
+	m, n := d.m, d.n
+	qr := NewDenseF64(m, n)
+	copy(qr.dat, d.dat)
+
+	k := n
+	if m < k {
+		k = m
+	}
+	tau := make([]float64, k)
+
+	for col := 0; col < k; col++ {
+		normX := 0.0
+		for i := col; i < m; i++ {
+			v := qr.At(i, col)
+			normX += v * v
+		}
+		normX = math.Sqrt(normX)
+		if normX == 0 {
+			continue
+		}
+
+		alpha := qr.At(col, col)
+		beta := normX
+		if alpha > 0 {
+			beta = -normX
+		}
+		v0 := alpha - beta
+		if v0 == 0 {
+			continue
+		}
+
+		for i := col + 1; i < m; i++ {
+			qr.Set(i, col, qr.At(i, col)/v0)
+		}
+		tau[col] = (beta - alpha) / beta
+		qr.Set(col, col, beta)
+
+		for j := col + 1; j < n; j++ {
+			sum := qr.At(col, j)
+			for i := col + 1; i < m; i++ {
+				sum += qr.At(i, col) * qr.At(i, j)
+			}
+			tauSum := tau[col] * sum
+			qr.Set(col, j, qr.At(col, j)-tauSum)
+			for i := col + 1; i < m; i++ {
+				qr.Set(i, j, qr.At(i, j)-tauSum*qr.At(i, col))
+			}
+		}
+	}
+
+	return &QRDenseF64{qr: qr, tau: tau, m: m, n: n}, nil
+}
+
+// applyQT applies Q^T to b in place, via the same reflectors used to build R.
+func (f *QRDenseF64) applyQT(b *DenseF64) {
+	for col := 0; col < len(f.tau); col++ {
+		if f.tau[col] == 0 {
+			continue
+		}
+		for j := 0; j < b.n; j++ {
+			sum := b.At(col, j)
+			for i := col + 1; i < f.m; i++ {
+				sum += f.qr.At(i, col) * b.At(i, j)
+			}
+			tauSum := f.tau[col] * sum
+			b.Set(col, j, b.At(col, j)-tauSum)
+			for i := col + 1; i < f.m; i++ {
+				b.Set(i, j, b.At(i, j)-tauSum*f.qr.At(i, col))
+			}
+		}
+	}
+}
+
+// Explicit materializes the compact Householder representation into
+// explicit, dense Q (m x m, orthogonal) and R (m x n) factors.
+func (f *QRDenseF64) Explicit() (q, r *DenseF64) {
+	r = NewDenseF64(f.m, f.n)
+	for i := 0; i < f.m; i++ {
+		for j := i; j < f.n; j++ {
+			r.Set(i, j, f.qr.At(i, j))
+		}
+	}
+
+	q = IdentityF64(f.m)
+	for col := len(f.tau) - 1; col >= 0; col-- {
+		if f.tau[col] == 0 {
+			continue
+		}
+		for j := 0; j < f.m; j++ {
+			sum := q.At(col, j)
+			for i := col + 1; i < f.m; i++ {
+				sum += f.qr.At(i, col) * q.At(i, j)
+			}
+			tauSum := f.tau[col] * sum
+			q.Set(col, j, q.At(col, j)-tauSum)
+			for i := col + 1; i < f.m; i++ {
+				q.Set(i, j, q.At(i, j)-tauSum*f.qr.At(i, col))
+			}
+		}
+	}
+
+	return q, r
+}
+
+// Solve solves the least-squares problem min ||A*x - b|| via Q^T b
+// followed by back substitution against R. Requires m >= n; a zero
+// diagonal entry of R returns a *SingularMatrixError (A doesn't have
+// full column rank).
+func (f *QRDenseF64) Solve(b *DenseF64) (*DenseF64, error) {
+	if b.m != f.m {
+		return nil, &SingularMatrixError{Column: -1, Tolerance: PivotTolerance}
+	}
+	if f.m < f.n {
+		return nil, &SingularMatrixError{Column: -1, Tolerance: PivotTolerance}
+	}
+
+	qtb := NewDenseF64(f.m, b.n)
+	for i := 0; i < f.m; i++ {
+		for j := 0; j < b.n; j++ {
+			qtb.Set(i, j, b.At(i, j))
+		}
+	}
+	f.applyQT(qtb)
+
+	x := NewDenseF64(f.n, b.n)
+	for col := 0; col < b.n; col++ {
+		for i := f.n - 1; i >= 0; i-- {
+			sum := qtb.At(i, col)
+			for k := i + 1; k < f.n; k++ {
+				sum -= f.qr.At(i, k) * x.At(k, col)
+			}
+			rii := f.qr.At(i, i)
+			if math.Abs(rii) < PivotTolerance {
+				return nil, &SingularMatrixError{Column: i, Tolerance: PivotTolerance}
+			}
+			x.Set(i, col, sum/rii)
+		}
+	}
+
+	return x, nil
+}
+
+// Solve solves A*x = b (or, for non-square A with m > n, the least
+// squares problem min ||A*x - b||), dispatching to LU for square A and to
+// QR otherwise.
+func (d *DenseF64) Solve(b *DenseF64) (*DenseF64, error) {
+	if d.m == d.n {
+		f, err := d.LU()
+		if err != nil {
+			return nil, err
+		}
+		return f.Solve(b)
+	}
+
+	f, err := d.QR()
+	if err != nil {
+		return nil, err
+	}
+	return f.Solve(b)
+}
@@ -0,0 +1,229 @@
+package mathgl
+
+import (
+	"runtime"
+	"sync"
+)
+
+// GrainSize is the largest dimension (rows, columns, or the shared inner
+// dimension) mulRecursiveF64/mulRecursiveF32 leave whole: a dimension above
+// it is halved, one at or below it is not split again, and
+// once every dimension of a sub-problem is at or below GrainSize, the
+// recursion bottoms out into the sequential cache-blocked kernel from
+// blockmul.go instead of forking further. Tune this down for small
+// matrices with many cores, up if goroutine overhead starts to show on
+// profiles.
+var GrainSize = 64
+
+// RecursiveMulParallelism caps how many goroutines mulRecursiveF64/
+// mulRecursiveF32 run sub-products on across an entire top-level Mul call
+// (not just per recursion level): once that many goroutines are in flight,
+// the remaining sub-products run inline on the caller instead of forking.
+// The top-level caller also runs sub-products inline, so up to
+// RecursiveMulParallelism+1 can be computing at once.
+// Defaults to runtime.GOMAXPROCS(0).
+var RecursiveMulParallelism = runtime.GOMAXPROCS(0)
+
+// seg is a half-open [lo, hi) range produced by splitRange.
+type seg struct{ lo, hi int }
+
+// splitRange halves [0, n) once, unless n is already small enough that
+// splitting it further wouldn't be useful, in which case it's returned
+// whole. This keeps a dimension that's already <= grain (e.g. n=1 for a
+// matrix-vector product) from being pointlessly re-split just because
+// another dimension of the same sub-problem is still oversized.
+func splitRange(n, grain int) []seg {
+	if n <= grain {
+		return []seg{{0, n}}
+	}
+	h := n / 2
+	return []seg{{0, h}, {h, n}}
+}
+
+// newRecursiveMulSem allocates the semaphore a top-level Mul call threads
+// through its whole recursion tree to bound total goroutine fan-out.
+func newRecursiveMulSem() chan struct{} {
+	n := RecursiveMulParallelism
+	if n < 1 {
+		n = 1
+	}
+	return make(chan struct{}, n)
+}
+
+// runRecursiveTask runs fn on its own goroutine if sem has room, or inline
+// on the caller otherwise, so the number of goroutines it has started that
+// are still running across a whole Mul call never exceeds cap(sem)
+// regardless of how many recursion levels are forking at once.
+func runRecursiveTask(sem chan struct{}, wg *sync.WaitGroup, fn func()) {
+	select {
+	case sem <- struct{}{}:
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			fn()
+		}()
+	default:
+		defer wg.Done()
+		fn()
+	}
+}
+
+// view returns a DenseF64 sharing d's backing array, describing the
+// sub-matrix d[i0:i1, j0:j1]. It shares d's stride, so writes through the
+// view land in the right place in d.
+func (d *DenseF64) view(i0, i1, j0, j1 int) *DenseF64 {
+	return &DenseF64{m: i1 - i0, n: j1 - j0, stride: d.stride, dat: d.dat[i0*d.stride+j0:]}
+}
+
+// addFrom sets dst[i,j] = a[i,j] + b[i,j] for every element, writing
+// through dst (typically a view into a larger result matrix).
+func (dst *DenseF64) addFrom(a, b *DenseF64) {
+	for i := 0; i < dst.m; i++ {
+		for j := 0; j < dst.n; j++ {
+			dst.Set(i, j, a.At(i, j)+b.At(i, j))
+		}
+	}
+}
+
+// copyFrom sets dst[i,j] = src[i,j] for every element, writing through dst.
+func (dst *DenseF64) copyFrom(src *DenseF64) {
+	for i := 0; i < dst.m; i++ {
+		for j := 0; j < dst.n; j++ {
+			dst.Set(i, j, src.At(i, j))
+		}
+	}
+}
+
+// sumIntoF64 writes the elementwise sum of results (of which there are only
+// ever 1 or 2, one per k-segment from splitRange) into dst.
+func sumIntoF64(dst *DenseF64, results []*DenseF64) {
+	if len(results) == 1 {
+		dst.copyFrom(results[0])
+		return
+	}
+	dst.addFrom(results[0], results[1])
+}
+
+// mulRecursiveF64 computes a*b with a fork/join divide-and-conquer: each
+// of a and b's dimensions that still exceeds GrainSize is halved (a
+// dimension already at or below GrainSize is left whole), the resulting
+// sub-products are computed concurrently on at most RecursiveMulParallelism
+// goroutines at a time (plus the caller), and each output quadrant is the sum of the sub-products that
+// contribute to it along the (possibly split) k dimension.
+func mulRecursiveF64(a, b *DenseF64) *DenseF64 {
+	return mulRecursiveF64Sem(a, b, newRecursiveMulSem())
+}
+
+func mulRecursiveF64Sem(a, b *DenseF64, sem chan struct{}) *DenseF64 {
+	m, k, n := a.m, a.n, b.n
+
+	if m <= GrainSize && n <= GrainSize && k <= GrainSize {
+		r := NewDenseF64(m, n)
+		mulBlockF64(a, b, r, 0, m, 0, n, 0, k)
+		return r
+	}
+
+	mSegs, kSegs, nSegs := splitRange(m, GrainSize), splitRange(k, GrainSize), splitRange(n, GrainSize)
+
+	results := make([][][]*DenseF64, len(mSegs))
+	var wg sync.WaitGroup
+	for mi, ms := range mSegs {
+		results[mi] = make([][]*DenseF64, len(nSegs))
+		for ni, ns := range nSegs {
+			results[mi][ni] = make([]*DenseF64, len(kSegs))
+			for ki, ks := range kSegs {
+				av := a.view(ms.lo, ms.hi, ks.lo, ks.hi)
+				bv := b.view(ks.lo, ks.hi, ns.lo, ns.hi)
+				dst := &results[mi][ni][ki]
+				wg.Add(1)
+				runRecursiveTask(sem, &wg, func() {
+					*dst = mulRecursiveF64Sem(av, bv, sem)
+				})
+			}
+		}
+	}
+	wg.Wait()
+
+	r := NewDenseF64(m, n)
+	for mi, ms := range mSegs {
+		for ni, ns := range nSegs {
+			sumIntoF64(r.view(ms.lo, ms.hi, ns.lo, ns.hi), results[mi][ni])
+		}
+	}
+	return r
+}
+
+// view is the DenseF32 counterpart of (*DenseF64).view.
+func (d *DenseF32) view(i0, i1, j0, j1 int) *DenseF32 {
+	return &DenseF32{m: i1 - i0, n: j1 - j0, stride: d.stride, dat: d.dat[i0*d.stride+j0:]}
+}
+
+// addFrom is the DenseF32 counterpart of (*DenseF64).addFrom.
+func (dst *DenseF32) addFrom(a, b *DenseF32) {
+	for i := 0; i < dst.m; i++ {
+		for j := 0; j < dst.n; j++ {
+			dst.Set(i, j, a.At(i, j)+b.At(i, j))
+		}
+	}
+}
+
+// copyFrom is the DenseF32 counterpart of (*DenseF64).copyFrom.
+func (dst *DenseF32) copyFrom(src *DenseF32) {
+	for i := 0; i < dst.m; i++ {
+		for j := 0; j < dst.n; j++ {
+			dst.Set(i, j, src.At(i, j))
+		}
+	}
+}
+
+func sumIntoF32(dst *DenseF32, results []*DenseF32) {
+	if len(results) == 1 {
+		dst.copyFrom(results[0])
+		return
+	}
+	dst.addFrom(results[0], results[1])
+}
+
+// mulRecursiveF32 is the DenseF32 counterpart of mulRecursiveF64.
+func mulRecursiveF32(a, b *DenseF32) *DenseF32 {
+	return mulRecursiveF32Sem(a, b, newRecursiveMulSem())
+}
+
+func mulRecursiveF32Sem(a, b *DenseF32, sem chan struct{}) *DenseF32 {
+	m, k, n := a.m, a.n, b.n
+
+	if m <= GrainSize && n <= GrainSize && k <= GrainSize {
+		r := NewDenseF32(m, n)
+		mulBlockF32(a, b, r, 0, m, 0, n, 0, k)
+		return r
+	}
+
+	mSegs, kSegs, nSegs := splitRange(m, GrainSize), splitRange(k, GrainSize), splitRange(n, GrainSize)
+
+	results := make([][][]*DenseF32, len(mSegs))
+	var wg sync.WaitGroup
+	for mi, ms := range mSegs {
+		results[mi] = make([][]*DenseF32, len(nSegs))
+		for ni, ns := range nSegs {
+			results[mi][ni] = make([]*DenseF32, len(kSegs))
+			for ki, ks := range kSegs {
+				av := a.view(ms.lo, ms.hi, ks.lo, ks.hi)
+				bv := b.view(ks.lo, ks.hi, ns.lo, ns.hi)
+				dst := &results[mi][ni][ki]
+				wg.Add(1)
+				runRecursiveTask(sem, &wg, func() {
+					*dst = mulRecursiveF32Sem(av, bv, sem)
+				})
+			}
+		}
+	}
+	wg.Wait()
+
+	r := NewDenseF32(m, n)
+	for mi, ms := range mSegs {
+		for ni, ns := range nSegs {
+			sumIntoF32(r.view(ms.lo, ms.hi, ns.lo, ns.hi), results[mi][ni])
+		}
+	}
+	return r
+}
@@ -0,0 +1,109 @@
+package mathgl
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMulRecursiveF64AgreesWithNaive(t *testing.T) {
+	cases := []struct {
+		name    string
+		m, k, n int
+	}{
+		{"square, multiple grain levels", 200, 200, 200},
+		{"non-square, non-power-of-two", 37, 53, 29},
+		{"below grain size", 10, 6, 8},
+		{"degenerate n=1 (matrix-vector)", 200, 200, 1},
+		{"degenerate m=1 (vector-matrix)", 1, 200, 200},
+		{"degenerate k=1 (outer product)", 200, 1, 200},
+	}
+
+	origGrain := GrainSize
+	GrainSize = 32
+	defer func() { GrainSize = origGrain }()
+
+	seed := 0.0
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			a := NewDenseF64(c.m, c.k)
+			b := NewDenseF64(c.k, c.n)
+			fillDenseF64(a, &seed)
+			fillDenseF64(b, &seed)
+
+			want := naiveDenseF64Mul(a, b)
+			got := mulRecursiveF64(a, b)
+			assertDenseF64Equal(t, got, want, c.name)
+		})
+	}
+}
+
+func TestSplitRangeStopsAtGrainSize(t *testing.T) {
+	if got := splitRange(1, 32); len(got) != 1 || got[0] != (seg{0, 1}) {
+		t.Fatalf("splitRange(1, 32) = %v, want a single whole segment", got)
+	}
+	if got := splitRange(32, 32); len(got) != 1 {
+		t.Fatalf("splitRange(32, 32) = %v, want a single whole segment", got)
+	}
+	if got := splitRange(33, 32); len(got) != 2 {
+		t.Fatalf("splitRange(33, 32) = %v, want two segments", got)
+	}
+}
+
+// TestMulRecursiveF64BoundsGoroutines checks that runRecursiveTask, the
+// fork helper mulRecursiveF64Sem uses, never has more than
+// RecursiveMulParallelism probe tasks running at once across a many-level
+// recursion tree, and that mulRecursiveF64Sem still computes the right
+// product with that bound in place.
+func TestMulRecursiveF64BoundsGoroutines(t *testing.T) {
+	origGrain, origParallelism := GrainSize, RecursiveMulParallelism
+	GrainSize = 8
+	RecursiveMulParallelism = 2
+	defer func() {
+		GrainSize = origGrain
+		RecursiveMulParallelism = origParallelism
+	}()
+
+	var inFlight, maxInFlight int64
+	sem := make(chan struct{}, RecursiveMulParallelism)
+	var wg sync.WaitGroup
+	// probe's caller is responsible for calling wg.Done (matching
+	// runRecursiveTask's own contract, where the fn it's given must not
+	// call wg.Done itself); the root call below does so directly.
+	var probe func(lo int)
+	probe = func(lo int) {
+		n := atomic.AddInt64(&inFlight, 1)
+		for {
+			m := atomic.LoadInt64(&maxInFlight)
+			if n <= m || atomic.CompareAndSwapInt64(&maxInFlight, m, n) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		atomic.AddInt64(&inFlight, -1)
+		if lo < 8 {
+			wg.Add(2)
+			runRecursiveTask(sem, &wg, func() { probe(lo + 1) })
+			runRecursiveTask(sem, &wg, func() { probe(lo + 1) })
+		}
+	}
+	wg.Add(1)
+	probe(0)
+	wg.Done()
+	wg.Wait()
+
+	if max := atomic.LoadInt64(&maxInFlight); max > int64(RecursiveMulParallelism) {
+		t.Fatalf("observed %d sub-products running concurrently, want <= RecursiveMulParallelism (%d)", max, RecursiveMulParallelism)
+	}
+
+	a := NewDenseF64(64, 64)
+	b := NewDenseF64(64, 64)
+	seed := 0.0
+	fillDenseF64(a, &seed)
+	fillDenseF64(b, &seed)
+
+	got := mulRecursiveF64Sem(a, b, newRecursiveMulSem())
+	want := naiveDenseF64Mul(a, b)
+	assertDenseF64Equal(t, got, want, "bounded concurrency")
+}